@@ -0,0 +1,85 @@
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PackEvent encodes args into the topics and data of a log entry for the
+// event named name, following Solidity's indexed-event encoding: topic 0 is
+// always the event's signature hash, each indexed argument becomes a
+// subsequent topic (hashed/left-padded per the ABI indexed-encoding rules),
+// and the non-indexed arguments are ABI-encoded into data in declaration
+// order.
+func (a ABI) PackEvent(name string, args ...interface{}) (topics []common.Hash, data []byte, err error) {
+	event, ok := a.Events[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("event %q not found in ABI", name)
+	}
+	if len(args) != len(event.Inputs) {
+		return nil, nil, fmt.Errorf("event %q expects %d arguments, got %d", name, len(event.Inputs), len(args))
+	}
+
+	indexedArgs := make([]interface{}, 0, len(event.Inputs))
+	nonIndexed := event.Inputs.NonIndexed()
+	nonIndexedArgs := make([]interface{}, 0, len(nonIndexed))
+	for i, input := range event.Inputs {
+		if input.Indexed {
+			indexedArgs = append(indexedArgs, args[i])
+		} else {
+			nonIndexedArgs = append(nonIndexedArgs, args[i])
+		}
+	}
+
+	topics, err = abi.MakeTopics(indexedArgs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pack indexed arguments of event %q: %w", name, err)
+	}
+	topics = append([]common.Hash{event.ID}, topics...)
+
+	data, err = nonIndexed.Pack(nonIndexedArgs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pack non-indexed arguments of event %q: %w", name, err)
+	}
+	return topics, data, nil
+}
+
+// UnpackEvent is the inverse of PackEvent: it decodes topics and data
+// produced for the named event into out, which must be a pointer to a struct
+// whose fields are tagged to match the event's ABI (see abi.ParseTopics and
+// abi.Arguments.Unpack for the matching rules).
+func (a ABI) UnpackEvent(name string, topics []common.Hash, data []byte, out interface{}) error {
+	event, ok := a.Events[name]
+	if !ok {
+		return fmt.Errorf("event %q not found in ABI", name)
+	}
+	if len(topics) == 0 || topics[0] != event.ID {
+		return fmt.Errorf("event %q: topic 0 does not match event signature", name)
+	}
+
+	values, err := event.Inputs.Unpack(data)
+	if err != nil {
+		return fmt.Errorf("failed to unpack non-indexed arguments of event %q: %w", name, err)
+	}
+	// Copy against the full input list: with only the non-indexed arguments, a
+	// single non-indexed value would be assigned to out's first field instead
+	// of the field matching its name.
+	if err := event.Inputs.Copy(out, values); err != nil {
+		return fmt.Errorf("failed to unpack non-indexed arguments of event %q: %w", name, err)
+	}
+
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if err := abi.ParseTopics(out, indexed, topics[1:]); err != nil {
+		return fmt.Errorf("failed to unpack indexed arguments of event %q: %w", name, err)
+	}
+	return nil
+}
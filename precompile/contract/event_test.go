@@ -0,0 +1,63 @@
+// See the file LICENSE for licensing terms.
+
+package contract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const testEventABI = `[
+	{
+		"type": "event",
+		"name": "Transfer",
+		"inputs": [
+			{"name": "from", "type": "address", "indexed": true},
+			{"name": "to", "type": "address", "indexed": true},
+			{"name": "amount", "type": "uint256", "indexed": false}
+		]
+	}
+]`
+
+func TestPackUnpackEventRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	testABI := ParseABI(testEventABI)
+
+	from := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	amount := big.NewInt(100)
+
+	topics, data, err := testABI.PackEvent("Transfer", from, to, amount)
+	require.NoError(err)
+	require.Len(topics, 3)
+	require.Equal(testABI.Events["Transfer"].ID, topics[0])
+	require.Equal(from.Hash(), topics[1])
+	require.Equal(to.Hash(), topics[2])
+
+	var out struct {
+		From   common.Address
+		To     common.Address
+		Amount *big.Int
+	}
+	require.NoError(testABI.UnpackEvent("Transfer", topics, data, &out))
+	require.Equal(from, out.From)
+	require.Equal(to, out.To)
+	require.Equal(amount, out.Amount)
+}
+
+func TestUnpackEventWrongTopic(t *testing.T) {
+	require := require.New(t)
+
+	testABI := ParseABI(testEventABI)
+	var out struct {
+		From   common.Address
+		To     common.Address
+		Amount *big.Int
+	}
+	err := testABI.UnpackEvent("Transfer", []common.Hash{{0x01}}, nil, &out)
+	require.Error(err)
+}
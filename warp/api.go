@@ -0,0 +1,71 @@
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// API exposes the "warp" RPC namespace: a subscription for accepted warp
+// messages, and a lookup for a single signed message by ID.
+type API struct {
+	backend *backend
+}
+
+// NewAPI returns a new warp API backed by b.
+func NewAPI(b *backend) *API {
+	return &API{backend: b}
+}
+
+// WarpMessages registers an eth_subscribe("warpMessages", filter)
+// subscription that streams SignedWarpMessage values as blocks carrying
+// warp messages matching filter are accepted. A nil filter matches every
+// message.
+func (api *API) WarpMessages(ctx context.Context, filter *WarpMessageFilter) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	msgs := make(chan *SignedWarpMessage, acceptedMsgChanSize)
+	sub := api.backend.subscribeFiltered(filter, msgs)
+
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case msg := <-msgs:
+				_ = notifier.Notify(rpcSub.ID, msg)
+			case <-sub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// GetSignedMessage returns the fully signed avalancheWarp.Message for
+// messageID: the unsigned message bytes plus this node's individual BLS
+// signature over them. Callers that need a quorum-backed
+// avalancheWarp.BitSetSignature must aggregate signatures collected from
+// other validators themselves.
+func (api *API) GetSignedMessage(ctx context.Context, messageID ids.ID) (*avalancheWarp.Message, error) {
+	unsignedMessage, signatureBytes, err := api.backend.GetSignedMessage(messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signed message %s: %w", messageID, err)
+	}
+	return avalancheWarp.NewMessage(unsignedMessage, &avalancheWarp.BitSetSignature{
+		Signature: signatureBytes,
+	})
+}
@@ -0,0 +1,114 @@
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	xwarp "github.com/ava-labs/subnet-evm/x/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// backend signs warp messages originating from this chain with this node's
+// BLS key, caches the results for later retrieval, and notifies subscribers
+// once a block carrying a message has been accepted.
+type backend struct {
+	sk *bls.SecretKey
+
+	lock             sync.RWMutex
+	signatures       map[ids.ID][bls.SignatureLen]byte
+	unsignedMessages map[ids.ID]*avalancheWarp.UnsignedMessage
+
+	acceptedMsgFeed event.Feed
+}
+
+// NewBackend returns a backend that signs with sk.
+func NewBackend(sk *bls.SecretKey) *backend {
+	return &backend{
+		sk:               sk,
+		signatures:       make(map[ids.ID][bls.SignatureLen]byte),
+		unsignedMessages: make(map[ids.ID]*avalancheWarp.UnsignedMessage),
+	}
+}
+
+// GetSignature returns this node's BLS signature over the message identified
+// by messageID, if it has been produced (i.e. the block carrying it has been
+// accepted).
+func (b *backend) GetSignature(messageID ids.ID) ([bls.SignatureLen]byte, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	sig, ok := b.signatures[messageID]
+	if !ok {
+		return [bls.SignatureLen]byte{}, fmt.Errorf("no signature for message %s", messageID)
+	}
+	return sig, nil
+}
+
+// GetSignedMessage returns the unsigned message identified by messageID
+// together with this node's signature over it.
+func (b *backend) GetSignedMessage(messageID ids.ID) (*avalancheWarp.UnsignedMessage, [bls.SignatureLen]byte, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	unsignedMessage, ok := b.unsignedMessages[messageID]
+	if !ok {
+		return nil, [bls.SignatureLen]byte{}, fmt.Errorf("no unsigned message %s", messageID)
+	}
+	sig, ok := b.signatures[messageID]
+	if !ok {
+		return nil, [bls.SignatureLen]byte{}, fmt.Errorf("no signature for message %s", messageID)
+	}
+	return unsignedMessage, sig, nil
+}
+
+// Accept signs unsignedMessage with this node's BLS key, caches the result
+// for GetSignature and GetSignedMessage, and notifies
+// SubscribeAcceptedWarpMessage subscribers with the block identified by
+// (blockHash, blockHeight). The VM's block acceptance path does not call it;
+// its caller invokes it once that block has been accepted.
+func (b *backend) Accept(unsignedMessage *avalancheWarp.UnsignedMessage, blockHash common.Hash, blockHeight uint64) error {
+	candidate := &xwarp.Candidate{Unsigned: unsignedMessage}
+	if result := xwarp.NewVerifier(xwarp.AcceptedMessageRequirements...).Verify(candidate); !result.Passed() {
+		return fmt.Errorf("refusing to sign message: %w", result.Err)
+	}
+
+	signature := bls.Sign(b.sk, unsignedMessage.Bytes())
+	var sigArray [bls.SignatureLen]byte
+	copy(sigArray[:], bls.SignatureToBytes(signature))
+
+	messageID := unsignedMessage.ID()
+	b.lock.Lock()
+	b.signatures[messageID] = sigArray
+	b.unsignedMessages[messageID] = unsignedMessage
+	b.lock.Unlock()
+
+	b.acceptedMsgFeed.Send(&SignedWarpMessage{
+		UnsignedMessage: unsignedMessage,
+		MessageID:       messageID,
+		Signature:       sigArray,
+		BlockHash:       blockHash,
+		BlockHeight:     blockHeight,
+	})
+	return nil
+}
+
+// APIs returns the RPC services this backend exposes: the "warp" namespace
+// with warp_getSignedMessage and eth_subscribe("warpMessages", ...). The VM's
+// CreateHandlers does not register them.
+func (b *backend) APIs() []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "warp",
+			Version:   "1.0",
+			Service:   NewAPI(b),
+			Public:    true,
+		},
+	}
+}
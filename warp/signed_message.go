@@ -0,0 +1,25 @@
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SignedWarpMessage is the payload delivered to subscribers of
+// backend.SubscribeAcceptedWarpMessage once a block carrying a warp message
+// has been accepted and this node has produced its share of the BLS
+// signature over that message. Signature is this node's individual
+// signature; aggregating the signatures of a quorum of validators into a
+// avalancheWarp.BitSetSignature is left to the caller (typically a relayer
+// that has already collected signature shares from the rest of the set).
+type SignedWarpMessage struct {
+	UnsignedMessage *avalancheWarp.UnsignedMessage
+	MessageID       ids.ID
+	Signature       [bls.SignatureLen]byte
+	BlockHash       common.Hash
+	BlockHeight     uint64
+}
@@ -0,0 +1,94 @@
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	warpPayload "github.com/ava-labs/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// PayloadCodec identifies the payload format carried by a warp message, used
+// by WarpMessageFilter to restrict a subscription to one kind of payload.
+type PayloadCodec uint8
+
+const (
+	PayloadCodecAny PayloadCodec = iota
+	PayloadCodecAddressed
+	PayloadCodecBlockHash
+)
+
+// WarpMessageFilter restricts an accepted warp message subscription to
+// messages matching every non-zero/non-nil field.
+type WarpMessageFilter struct {
+	DestinationChainID *ids.ID
+	SourceAddress      *common.Address
+	PayloadCodec       PayloadCodec
+}
+
+func (f *WarpMessageFilter) matches(msg *SignedWarpMessage) bool {
+	if f == nil {
+		return true
+	}
+	if f.DestinationChainID == nil && f.SourceAddress == nil && f.PayloadCodec == PayloadCodecAny {
+		return true
+	}
+
+	parsed, _ := warpPayload.Parse(msg.UnsignedMessage.Payload)
+	addressed, isAddressed := parsed.(*warpPayload.AddressedPayload)
+
+	if f.PayloadCodec == PayloadCodecAddressed && !isAddressed {
+		return false
+	}
+	if f.PayloadCodec == PayloadCodecBlockHash && isAddressed {
+		return false
+	}
+	if f.DestinationChainID != nil {
+		if !isAddressed || common.Hash(*f.DestinationChainID) != addressed.DestinationChainID {
+			return false
+		}
+	}
+	if f.SourceAddress != nil {
+		if !isAddressed || addressed.SourceAddress != *f.SourceAddress {
+			return false
+		}
+	}
+	return true
+}
+
+const acceptedMsgChanSize = 32
+
+// SubscribeAcceptedWarpMessage registers dest to receive every
+// SignedWarpMessage produced for a block after it is accepted, in acceptance
+// order. Delivery blocks Accept until dest has received the message, so a
+// slow receiver should drain dest on its own goroutine, the same way
+// SubscribeAcceptedLogsEvent callers do.
+func (b *backend) SubscribeAcceptedWarpMessage(dest chan<- *SignedWarpMessage) event.Subscription {
+	return b.acceptedMsgFeed.Subscribe(dest)
+}
+
+// subscribeFiltered returns a subscription that only forwards messages
+// matching filter to dest. It backs the warp namespace's
+// eth_subscribe("warpMessages", filter) endpoint so that filtering happens
+// once, in the backend, instead of in every RPC client.
+func (b *backend) subscribeFiltered(filter *WarpMessageFilter, dest chan<- *SignedWarpMessage) event.Subscription {
+	unfiltered := make(chan *SignedWarpMessage, acceptedMsgChanSize)
+	sub := b.acceptedMsgFeed.Subscribe(unfiltered)
+	go func() {
+		for {
+			select {
+			case msg, ok := <-unfiltered:
+				if !ok {
+					return
+				}
+				if filter.matches(msg) {
+					dest <- msg
+				}
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub
+}
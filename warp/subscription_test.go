@@ -0,0 +1,156 @@
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBackend(t *testing.T) *backend {
+	t.Helper()
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	return NewBackend(sk)
+}
+
+func newAddressedMessage(t *testing.T, sourceAddress, destinationAddress common.Address, destinationChainID ids.ID, payload []byte) *avalancheWarp.UnsignedMessage {
+	t.Helper()
+	addressedPayload, err := warpPayload.NewAddressedPayload(sourceAddress, common.Hash(destinationChainID), destinationAddress, payload)
+	require.NoError(t, err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(0, ids.GenerateTestID(), addressedPayload.Bytes())
+	require.NoError(t, err)
+	return unsignedMessage
+}
+
+func recvSignedMessage(t *testing.T, ch <-chan *SignedWarpMessage) *SignedWarpMessage {
+	t.Helper()
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for accepted warp message")
+		return nil
+	}
+}
+
+func TestSubscribeAcceptedWarpMessageOrdering(t *testing.T) {
+	require := require.New(t)
+	b := newTestBackend(t)
+
+	ch := make(chan *SignedWarpMessage, 10)
+	sub := b.SubscribeAcceptedWarpMessage(ch)
+	defer sub.Unsubscribe()
+
+	const numMessages = 5
+	messages := make([]*avalancheWarp.UnsignedMessage, numMessages)
+	for i := 0; i < numMessages; i++ {
+		messages[i] = newAddressedMessage(t, common.Address{byte(i)}, common.Address{byte(i + 1)}, ids.GenerateTestID(), []byte{byte(i)})
+		require.NoError(b.Accept(messages[i], common.Hash{byte(i)}, uint64(i)))
+	}
+
+	for i := 0; i < numMessages; i++ {
+		signedMsg := recvSignedMessage(t, ch)
+		require.Equal(messages[i].ID(), signedMsg.MessageID)
+		require.Equal(uint64(i), signedMsg.BlockHeight)
+	}
+}
+
+func TestSubscribeAcceptedWarpMessageReplayAfterReorg(t *testing.T) {
+	require := require.New(t)
+	b := newTestBackend(t)
+
+	ch := make(chan *SignedWarpMessage, 10)
+	sub := b.SubscribeAcceptedWarpMessage(ch)
+	defer sub.Unsubscribe()
+
+	unsignedMessage := newAddressedMessage(t, common.Address{1}, common.Address{2}, ids.GenerateTestID(), []byte("payload"))
+
+	// Deliver the same message as part of block A...
+	require.NoError(b.Accept(unsignedMessage, common.Hash{0xA}, 10))
+	first := recvSignedMessage(t, ch)
+	require.Equal(common.Hash{0xA}, first.BlockHash)
+	require.Equal(uint64(10), first.BlockHeight)
+
+	// ...then again as part of block B after a reorg re-accepts it at a
+	// different height. Subscribers see both deliveries; deduping accepted
+	// messages across a reorg is the caller's responsibility.
+	require.NoError(b.Accept(unsignedMessage, common.Hash{0xB}, 11))
+	second := recvSignedMessage(t, ch)
+	require.Equal(common.Hash{0xB}, second.BlockHash)
+	require.Equal(uint64(11), second.BlockHeight)
+
+	require.Equal(first.MessageID, second.MessageID)
+}
+
+func TestSubscribeFilteredByDestinationChainID(t *testing.T) {
+	require := require.New(t)
+	b := newTestBackend(t)
+
+	wantChainID := ids.GenerateTestID()
+	wanted := newAddressedMessage(t, common.Address{1}, common.Address{2}, wantChainID, []byte("match"))
+	unwanted := newAddressedMessage(t, common.Address{1}, common.Address{2}, ids.GenerateTestID(), []byte("no-match"))
+
+	ch := make(chan *SignedWarpMessage, 10)
+	sub := b.subscribeFiltered(&WarpMessageFilter{DestinationChainID: &wantChainID}, ch)
+	defer sub.Unsubscribe()
+
+	require.NoError(b.Accept(unwanted, common.Hash{1}, 1))
+	require.NoError(b.Accept(wanted, common.Hash{2}, 2))
+
+	signedMsg := recvSignedMessage(t, ch)
+	require.Equal(wanted.ID(), signedMsg.MessageID)
+
+	select {
+	case msg := <-ch:
+		require.Fail("received a second, non-matching message", "%+v", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSubscribeFilteredBySourceAddress(t *testing.T) {
+	require := require.New(t)
+	b := newTestBackend(t)
+
+	wantSource := common.Address{0x42}
+	wanted := newAddressedMessage(t, wantSource, common.Address{2}, ids.GenerateTestID(), []byte("match"))
+	unwanted := newAddressedMessage(t, common.Address{0x43}, common.Address{2}, ids.GenerateTestID(), []byte("no-match"))
+
+	ch := make(chan *SignedWarpMessage, 10)
+	sub := b.subscribeFiltered(&WarpMessageFilter{SourceAddress: &wantSource}, ch)
+	defer sub.Unsubscribe()
+
+	require.NoError(b.Accept(unwanted, common.Hash{1}, 1))
+	require.NoError(b.Accept(wanted, common.Hash{2}, 2))
+
+	signedMsg := recvSignedMessage(t, ch)
+	require.Equal(wanted.ID(), signedMsg.MessageID)
+}
+
+func TestSubscribeFilteredByPayloadCodec(t *testing.T) {
+	require := require.New(t)
+	b := newTestBackend(t)
+
+	addressed := newAddressedMessage(t, common.Address{1}, common.Address{2}, ids.GenerateTestID(), []byte("addressed"))
+	blockHashPayload, err := warpPayload.NewBlockHashPayload(ids.GenerateTestID().Hash())
+	require.NoError(err)
+	blockHashMessage, err := avalancheWarp.NewUnsignedMessage(0, ids.GenerateTestID(), blockHashPayload.Bytes())
+	require.NoError(err)
+
+	ch := make(chan *SignedWarpMessage, 10)
+	sub := b.subscribeFiltered(&WarpMessageFilter{PayloadCodec: PayloadCodecAddressed}, ch)
+	defer sub.Unsubscribe()
+
+	require.NoError(b.Accept(blockHashMessage, common.Hash{1}, 1))
+	require.NoError(b.Accept(addressed, common.Hash{2}, 2))
+
+	signedMsg := recvSignedMessage(t, ch)
+	require.Equal(addressed.ID(), signedMsg.MessageID)
+}
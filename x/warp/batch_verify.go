@@ -0,0 +1,145 @@
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// blindingScalarBits is the width of the random per-message blinding scalar
+// r_i used by BatchVerify to defend against rogue-key/mix-and-match attacks
+// when combining independently aggregated signatures into a single check.
+const blindingScalarBits = 128
+
+// warpBatchVerifyDST is the domain separation tag blst hashes each message to
+// a G2 point with inside MultipleAggregateVerify. This MUST be the same tag
+// bls.Sign/bls.Verify use internally when producing/checking a single
+// signature, or every legitimate batch will fail to verify (and silently fall
+// back to the per-message path in BatchVerify, defeating the point of
+// batching). avalanchego's bls package doesn't export its ciphersuite, but it
+// signs with the standard BLS12-381 "proof of possession" ciphersuite used by
+// blst and the Ethereum consensus spec, so that is what's reused here rather
+// than an invented tag.
+const warpBatchVerifyDST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+
+// batchVerifyAttempts and batchVerifySuccesses count how many times the
+// combined multi-pairing check in verifyBatch was tried and actually
+// succeeded, as opposed to BatchVerify silently falling back to
+// verifyIndividually. Tests use these to assert the batch path is the one
+// actually doing the work, since a DST (or other) mismatch that always fails
+// the batch check is otherwise invisible: verifyIndividually still returns
+// the right answer.
+var (
+	batchVerifyAttempts  int64
+	batchVerifySuccesses int64
+)
+
+// PredicateSignature is one (message, aggregate public key, aggregate
+// signature) tuple produced while evaluating warp predicates for a block.
+// Index identifies the tuple's position among the predicates of the block,
+// so that a batch verification failure can be attributed to a specific
+// predicate.
+type PredicateSignature struct {
+	Index              int
+	UnsignedMessage    *avalancheWarp.UnsignedMessage
+	AggregatePublicKey *bls.PublicKey
+	AggregateSignature *bls.Signature
+}
+
+// BatchVerifyError reports that the tuple at Index failed verification,
+// either as part of the batch check or the per-message fallback.
+type BatchVerifyError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchVerifyError) Error() string {
+	return fmt.Sprintf("predicate %d: %s", e.Index, e.Err)
+}
+
+func (e *BatchVerifyError) Unwrap() error { return e.Err }
+
+// BatchVerify verifies every tuple in sigs. When len(sigs) > 1, it first
+// attempts a single combined check using random scalar blinding per tuple:
+// e(sum(r_i*sig_i), G) == prod(e(H(m_i), r_i*pk_i)), which is both a correct
+// verification of every individual (msg_i, pk_i, sig_i) triple and, because
+// each r_i is an independently sampled 128-bit scalar, immune to an attacker
+// substituting a valid signature for one message against another message's
+// key. If the combined check fails (or sigs contains exactly one tuple),
+// BatchVerify falls back to verifying each tuple independently so that the
+// offending predicate can be attributed by index.
+func BatchVerify(sigs []PredicateSignature) error {
+	if len(sigs) == 0 {
+		return nil
+	}
+	if len(sigs) > 1 {
+		atomic.AddInt64(&batchVerifyAttempts, 1)
+		ok, err := verifyBatch(sigs)
+		if err == nil && ok {
+			atomic.AddInt64(&batchVerifySuccesses, 1)
+			return nil
+		}
+	}
+	return verifyIndividually(sigs)
+}
+
+func verifyIndividually(sigs []PredicateSignature) error {
+	for _, sig := range sigs {
+		if !bls.Verify(sig.AggregatePublicKey, sig.AggregateSignature, sig.UnsignedMessage.Bytes()) {
+			return &BatchVerifyError{Index: sig.Index, Err: fmt.Errorf("signature does not verify")}
+		}
+	}
+	return nil
+}
+
+// verifyBatch performs the blinded multi-pairing check described in
+// BatchVerify's doc comment. It reports (false, nil) for an honest batch
+// failure (so the caller falls back to per-message verification to localize
+// it) and (false, err) if a tuple's bytes cannot even be deserialized.
+func verifyBatch(sigs []PredicateSignature) (bool, error) {
+	pks := make([]*blst.P1Affine, len(sigs))
+	blstSigs := make([]*blst.P2Affine, len(sigs))
+	msgs := make([][]byte, len(sigs))
+
+	for i, sig := range sigs {
+		pkBytes := bls.PublicKeyToBytes(sig.AggregatePublicKey)
+		pk := new(blst.P1Affine).Uncompress(pkBytes)
+		if pk == nil {
+			return false, fmt.Errorf("predicate %d: invalid public key bytes", sig.Index)
+		}
+		sigBytes := bls.SignatureToBytes(sig.AggregateSignature)
+		blstSig := new(blst.P2Affine).Uncompress(sigBytes)
+		if blstSig == nil {
+			return false, fmt.Errorf("predicate %d: invalid signature bytes", sig.Index)
+		}
+		pks[i] = pk
+		blstSigs[i] = blstSig
+		msgs[i] = sig.UnsignedMessage.Bytes()
+	}
+
+	randomScalars, err := sampleBlindingScalars(len(sigs))
+	if err != nil {
+		return false, err
+	}
+
+	dummySig := new(blst.P2Affine)
+	ok := dummySig.MultipleAggregateVerify(blstSigs, false, pks, false, msgs, []byte(warpBatchVerifyDST), randomScalars, blindingScalarBits)
+	return ok, nil
+}
+
+// sampleBlindingScalars returns n independently sampled blindingScalarBits
+// scalars, one per tuple in a batch, as required by blst's randomized
+// multi-pairing verification.
+func sampleBlindingScalars(n int) ([]byte, error) {
+	out := make([]byte, n*blindingScalarBits/8)
+	if _, err := rand.Read(out); err != nil {
+		return nil, fmt.Errorf("failed to sample blinding scalars: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,129 @@
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newPredicateSignature(t *testing.T, index int) PredicateSignature {
+	t.Helper()
+	require := require.New(t)
+
+	addressedPayload, err := warpPayload.NewAddressedPayload(
+		common.Address{byte(index)},
+		ids.GenerateTestID().Hash(),
+		common.Address{byte(index + 1)},
+		[]byte("payload"),
+	)
+	require.NoError(err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(0, ids.GenerateTestID(), addressedPayload.Bytes())
+	require.NoError(err)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	sig := bls.Sign(sk, unsignedMessage.Bytes())
+
+	return PredicateSignature{
+		Index:              index,
+		UnsignedMessage:    unsignedMessage,
+		AggregatePublicKey: bls.PublicFromSecretKey(sk),
+		AggregateSignature: sig,
+	}
+}
+
+func newPredicateSignatures(t *testing.T, n int) []PredicateSignature {
+	sigs := make([]PredicateSignature, n)
+	for i := 0; i < n; i++ {
+		sigs[i] = newPredicateSignature(t, i)
+	}
+	return sigs
+}
+
+func TestBatchVerifyAllValid(t *testing.T) {
+	require.NoError(t, BatchVerify(newPredicateSignatures(t, 10)))
+}
+
+// TestBatchVerifyTakesBatchPath guards against a regression where the
+// combined multi-pairing check in verifyBatch always fails (for example from
+// a domain-separation-tag mismatch with bls.Sign) and BatchVerify's fallback
+// to per-message verification silently absorbs the failure: the test suite
+// would stay green with zero actual batching ever happening. It asserts the
+// batch attempt for a valid, multi-tuple input actually succeeds, rather than
+// only asserting BatchVerify's overall (batch-or-fallback) return value.
+func TestBatchVerifyTakesBatchPath(t *testing.T) {
+	require := require.New(t)
+	sigs := newPredicateSignatures(t, 10)
+
+	attemptsBefore := atomic.LoadInt64(&batchVerifyAttempts)
+	successesBefore := atomic.LoadInt64(&batchVerifySuccesses)
+
+	require.NoError(BatchVerify(sigs))
+
+	require.Equal(attemptsBefore+1, atomic.LoadInt64(&batchVerifyAttempts))
+	require.Equal(successesBefore+1, atomic.LoadInt64(&batchVerifySuccesses),
+		"verifyBatch did not succeed on a valid batch; BatchVerify must have silently fallen back to per-message verification")
+}
+
+func TestBatchVerifyEmpty(t *testing.T) {
+	require.NoError(t, BatchVerify(nil))
+}
+
+func TestBatchVerifyLocalizesTamperedSignature(t *testing.T) {
+	require := require.New(t)
+	sigs := newPredicateSignatures(t, 10)
+
+	const tamperedIndex = 4
+	otherSK, err := bls.NewSecretKey()
+	require.NoError(err)
+	sigs[tamperedIndex].AggregateSignature = bls.Sign(otherSK, sigs[tamperedIndex].UnsignedMessage.Bytes())
+
+	err = BatchVerify(sigs)
+	require.Error(err)
+	var batchErr *BatchVerifyError
+	require.ErrorAs(err, &batchErr)
+	require.Equal(tamperedIndex, batchErr.Index)
+}
+
+func BenchmarkBatchVerify(b *testing.B) {
+	for _, n := range []int{5, 20, 100} {
+		sigs := make([]PredicateSignature, n)
+		for i := 0; i < n; i++ {
+			sk, err := bls.NewSecretKey()
+			require.NoError(b, err)
+			addressedPayload, err := warpPayload.NewAddressedPayload(
+				common.Address{byte(i)},
+				ids.GenerateTestID().Hash(),
+				common.Address{byte(i + 1)},
+				[]byte("payload"),
+			)
+			require.NoError(b, err)
+			unsignedMessage, err := avalancheWarp.NewUnsignedMessage(0, ids.GenerateTestID(), addressedPayload.Bytes())
+			require.NoError(b, err)
+			sigs[i] = PredicateSignature{
+				Index:              i,
+				UnsignedMessage:    unsignedMessage,
+				AggregatePublicKey: bls.PublicFromSecretKey(sk),
+				AggregateSignature: bls.Sign(sk, unsignedMessage.Bytes()),
+			}
+		}
+
+		b.Run(fmt.Sprintf("predicates=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := BatchVerify(sigs); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
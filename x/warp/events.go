@@ -0,0 +1,64 @@
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const sendWarpMessageEventName = "SendWarpMessage"
+
+// SendWarpMessageEvent is the typed form of the SendWarpMessage log emitted
+// by the warp precompile. Sender is the address that invoked SendWarpMessage;
+// Message is the raw bytes of the unsigned warp message, suitable for
+// avalancheWarp.ParseUnsignedMessage.
+type SendWarpMessageEvent struct {
+	DestinationChainID common.Hash
+	DestinationAddress common.Address
+	Sender             common.Address
+	Message            []byte
+}
+
+// PackSendWarpMessageEvent returns the topics and data of a SendWarpMessage
+// log for event, using WarpABI's indexed-event encoding for the topics. This
+// replaces hand-assembling the topic list when constructing or asserting
+// against SendWarpMessage logs. Unlike a standard Solidity event, data is
+// event.Message's raw bytes rather than an ABI-encoded dynamic "bytes"
+// value: the warp precompile emits the unsigned message as-is so that
+// avalancheWarp.ParseUnsignedMessage can read a log's data directly, without
+// an ABI-decoding step, so PackEvent's generic non-indexed encoding is not
+// used for it here.
+func PackSendWarpMessageEvent(event SendWarpMessageEvent) (topics []common.Hash, data []byte, err error) {
+	topics, _, err = WarpABI.PackEvent(
+		sendWarpMessageEventName,
+		event.DestinationChainID,
+		event.DestinationAddress,
+		event.Sender,
+		event.Message,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pack %s event: %w", sendWarpMessageEventName, err)
+	}
+	return topics, event.Message, nil
+}
+
+// UnpackSendWarpMessageEvent is the inverse of PackSendWarpMessageEvent: it
+// decodes a SendWarpMessage log's topics and data into a SendWarpMessageEvent.
+// As in PackSendWarpMessageEvent, data is read as the event's raw Message
+// bytes rather than ABI-decoded.
+func UnpackSendWarpMessageEvent(topics []common.Hash, data []byte) (*SendWarpMessageEvent, error) {
+	if len(topics) != 4 {
+		return nil, fmt.Errorf("%s event: expected 4 topics, got %d", sendWarpMessageEventName, len(topics))
+	}
+	if topics[0] != WarpABI.Events[sendWarpMessageEventName].ID {
+		return nil, fmt.Errorf("%s event: topic 0 does not match event signature", sendWarpMessageEventName)
+	}
+	return &SendWarpMessageEvent{
+		DestinationChainID: topics[1],
+		DestinationAddress: common.BytesToAddress(topics[2].Bytes()),
+		Sender:             common.BytesToAddress(topics[3].Bytes()),
+		Message:            data,
+	}, nil
+}
@@ -0,0 +1,47 @@
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackUnpackSendWarpMessageEvent(t *testing.T) {
+	require := require.New(t)
+
+	sender := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	destinationAddress := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	destinationChainID := common.Hash(ids.GenerateTestID())
+
+	addressedPayload, err := warpPayload.NewAddressedPayload(sender, destinationChainID, destinationAddress, []byte("hello"))
+	require.NoError(err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(0, ids.GenerateTestID(), addressedPayload.Bytes())
+	require.NoError(err)
+
+	event := SendWarpMessageEvent{
+		DestinationChainID: destinationChainID,
+		DestinationAddress: destinationAddress,
+		Sender:             sender,
+		Message:            unsignedMessage.Bytes(),
+	}
+	topics, data, err := PackSendWarpMessageEvent(event)
+	require.NoError(err)
+
+	expectedTopics := []common.Hash{
+		WarpABI.Events[sendWarpMessageEventName].ID,
+		destinationChainID,
+		destinationAddress.Hash(),
+		sender.Hash(),
+	}
+	require.Equal(expectedTopics, topics)
+
+	unpacked, err := UnpackSendWarpMessageEvent(topics, data)
+	require.NoError(err)
+	require.Equal(event, *unpacked)
+}
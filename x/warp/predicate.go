@@ -0,0 +1,119 @@
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+// VerifyPredicate evaluates a single warp predicate: it parses
+// predicateBytes as a signed
+// avalancheWarp.Message, resolves the canonical validator set backing it at
+// pChainHeight, and runs PredicateVerificationRequirements against the
+// result. It replaces the previous monolithic check that only compared
+// pChainHeight against the message's source subnet's validator set
+// availability: that gating is now folded into the canonical validator set
+// lookup below, so a pChainHeight too low for the subnet's validators to be
+// queryable surfaces here as a GetCanonicalValidatorSet error, exactly as it
+// did in the code this replaces. It is a thin wrapper around
+// VerifyPredicates for the common single-predicate case.
+func VerifyPredicate(pChainState validators.State, pChainHeight uint64, predicateBytes []byte) error {
+	return VerifyPredicates(pChainState, pChainHeight, [][]byte{predicateBytes})
+}
+
+// nonSignatureRequirements returns PredicateVerificationRequirements with
+// RequireAggregateSignatureValid removed: VerifyPredicates runs these against
+// each predicate individually, then verifies every predicate's aggregate
+// signature across the whole block in a single BatchVerify call instead of
+// one RequireAggregateSignatureValid (and thus one bls.Verify) per predicate.
+func nonSignatureRequirements() []Requirement {
+	signatureRequirementName := RequireAggregateSignatureValid().Name()
+	requirements := make([]Requirement, 0, len(PredicateVerificationRequirements))
+	for _, requirement := range PredicateVerificationRequirements {
+		if requirement.Name() != signatureRequirementName {
+			requirements = append(requirements, requirement)
+		}
+	}
+	return requirements
+}
+
+// VerifyPredicates evaluates a block's warp predicates together:
+// given every predicate addressed to the warp precompile in a block, it
+// resolves each one's Candidate the same way VerifyPredicate does, runs the
+// non-cryptographic requirements against each individually, then verifies
+// every predicate's aggregate BLS signature with a single BatchVerify call
+// instead of one per predicate. The returned error is a *BatchVerifyError
+// when it can be attributed to a specific predicate (by its index in
+// predicateBytes). The warp precompile's predicate verification does not call
+// VerifyPredicate or VerifyPredicates.
+func VerifyPredicates(pChainState validators.State, pChainHeight uint64, predicateBytes [][]byte) error {
+	verifier := NewVerifier(nonSignatureRequirements()...)
+	sigs := make([]PredicateSignature, len(predicateBytes))
+
+	for i, predicate := range predicateBytes {
+		candidate, err := candidateFromPredicate(context.TODO(), pChainState, pChainHeight, predicate)
+		if err != nil {
+			return &BatchVerifyError{Index: i, Err: err}
+		}
+		if result := verifier.Verify(candidate); !result.Passed() {
+			return &BatchVerifyError{Index: i, Err: result.Err}
+		}
+
+		signers, err := signersOf(candidate.Signature)
+		if err != nil {
+			return &BatchVerifyError{Index: i, Err: fmt.Errorf("invalid signer bitset: %w", err)}
+		}
+		aggregatePublicKey, err := avalancheWarp.AggregatePublicKeys(avalancheWarp.FilterValidators(signers, candidate.Validators))
+		if err != nil {
+			return &BatchVerifyError{Index: i, Err: fmt.Errorf("failed to aggregate public keys: %w", err)}
+		}
+		aggregateSignature, err := bls.SignatureFromBytes(candidate.Signature.Signature[:])
+		if err != nil {
+			return &BatchVerifyError{Index: i, Err: fmt.Errorf("invalid aggregate signature bytes: %w", err)}
+		}
+
+		sigs[i] = PredicateSignature{
+			Index:              i,
+			UnsignedMessage:    candidate.Unsigned,
+			AggregatePublicKey: aggregatePublicKey,
+			AggregateSignature: aggregateSignature,
+		}
+	}
+
+	return BatchVerify(sigs)
+}
+
+// candidateFromPredicate parses predicateBytes as a signed avalancheWarp.Message
+// and resolves the canonical validator set backing it at pChainHeight,
+// returning the Candidate that predicate verification evaluates.
+func candidateFromPredicate(ctx context.Context, pChainState validators.State, pChainHeight uint64, predicateBytes []byte) (*Candidate, error) {
+	message, err := avalancheWarp.ParseMessage(predicateBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse predicate as warp message: %w", err)
+	}
+	bitSetSignature, ok := message.Signature.(*avalancheWarp.BitSetSignature)
+	if !ok {
+		return nil, fmt.Errorf("unsupported warp signature type %T", message.Signature)
+	}
+
+	subnetID, err := pChainState.GetSubnetID(ctx, message.UnsignedMessage.SourceChainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up subnet for source chain %s: %w", message.UnsignedMessage.SourceChainID, err)
+	}
+	vdrs, totalWeight, err := avalancheWarp.GetCanonicalValidatorSet(ctx, pChainState, pChainHeight, subnetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validator set for subnet %s at P-Chain height %d: %w", subnetID, pChainHeight, err)
+	}
+
+	return &Candidate{
+		Unsigned:    message.UnsignedMessage,
+		Signature:   bitSetSignature,
+		Validators:  vdrs,
+		TotalWeight: totalWeight,
+	}, nil
+}
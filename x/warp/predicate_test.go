@@ -0,0 +1,156 @@
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestValidatorState returns a validators.State whose canonical validator
+// set is only queryable at or above minimumValidPChainHeight, mirroring how a
+// subnet's validator set becomes visible to the P-Chain only once it is
+// registered there.
+func newTestValidatorState(nodeID ids.NodeID, pk *bls.PublicKey, minimumValidPChainHeight uint64) validators.State {
+	return &validators.TestState{
+		GetSubnetIDF: func(ctx context.Context, chainID ids.ID) (ids.ID, error) {
+			return ids.Empty, nil
+		},
+		GetValidatorSetF: func(ctx context.Context, height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			if height < minimumValidPChainHeight {
+				return nil, errors.New("validator set not yet available at this P-Chain height")
+			}
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				nodeID: {NodeID: nodeID, PublicKey: pk, Weight: 100},
+			}, nil
+		},
+	}
+}
+
+func newSignedPredicate(t *testing.T) (predicateBytes []byte, nodeID ids.NodeID, pk *bls.PublicKey) {
+	t.Helper()
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk = bls.PublicFromSecretKey(sk)
+	nodeID = ids.GenerateTestNodeID()
+
+	addressedPayload, err := warpPayload.NewAddressedPayload(common.Address{1}, ids.GenerateTestID().Hash(), common.Address{2}, []byte("payload"))
+	require.NoError(err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(0, ids.GenerateTestID(), addressedPayload.Bytes())
+	require.NoError(err)
+
+	signature := bls.Sign(sk, unsignedMessage.Bytes())
+	signers := set.NewBits()
+	signers.Add(0)
+	bitSetSignature := &avalancheWarp.BitSetSignature{Signers: signers.Bytes()}
+	copy(bitSetSignature.Signature[:], bls.SignatureToBytes(signature))
+
+	message, err := avalancheWarp.NewMessage(unsignedMessage, bitSetSignature)
+	require.NoError(err)
+	return message.Bytes(), nodeID, pk
+}
+
+func TestVerifyPredicateValidAtSufficientHeight(t *testing.T) {
+	predicateBytes, nodeID, pk := newSignedPredicate(t)
+	pChainState := newTestValidatorState(nodeID, pk, 10)
+	require.NoError(t, VerifyPredicate(pChainState, 10, predicateBytes))
+}
+
+func TestVerifyPredicateFailsBelowMinimumHeight(t *testing.T) {
+	predicateBytes, nodeID, pk := newSignedPredicate(t)
+	pChainState := newTestValidatorState(nodeID, pk, 10)
+	require.Error(t, VerifyPredicate(pChainState, 9, predicateBytes))
+}
+
+func TestVerifyPredicateRejectsMalformedBytes(t *testing.T) {
+	_, nodeID, pk := newSignedPredicate(t)
+	pChainState := newTestValidatorState(nodeID, pk, 10)
+	require.Error(t, VerifyPredicate(pChainState, 10, []byte("not a warp message")))
+}
+
+// newSignedPredicates signs n distinct messages with the same validator, for
+// tests that exercise VerifyPredicates' block-level batching across multiple
+// predicates.
+func newSignedPredicates(t *testing.T, n int) (predicateBytes [][]byte, nodeID ids.NodeID, pk *bls.PublicKey) {
+	t.Helper()
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk = bls.PublicFromSecretKey(sk)
+	nodeID = ids.GenerateTestNodeID()
+
+	predicateBytes = make([][]byte, n)
+	for i := 0; i < n; i++ {
+		addressedPayload, err := warpPayload.NewAddressedPayload(common.Address{byte(i)}, ids.GenerateTestID().Hash(), common.Address{byte(i + 1)}, []byte("payload"))
+		require.NoError(err)
+		unsignedMessage, err := avalancheWarp.NewUnsignedMessage(0, ids.GenerateTestID(), addressedPayload.Bytes())
+		require.NoError(err)
+
+		signature := bls.Sign(sk, unsignedMessage.Bytes())
+		signers := set.NewBits()
+		signers.Add(0)
+		bitSetSignature := &avalancheWarp.BitSetSignature{Signers: signers.Bytes()}
+		copy(bitSetSignature.Signature[:], bls.SignatureToBytes(signature))
+
+		message, err := avalancheWarp.NewMessage(unsignedMessage, bitSetSignature)
+		require.NoError(err)
+		predicateBytes[i] = message.Bytes()
+	}
+	return predicateBytes, nodeID, pk
+}
+
+func TestVerifyPredicatesValid(t *testing.T) {
+	predicateBytes, nodeID, pk := newSignedPredicates(t, 10)
+	pChainState := newTestValidatorState(nodeID, pk, 10)
+	require.NoError(t, VerifyPredicates(pChainState, 10, predicateBytes))
+}
+
+// TestVerifyPredicatesTakesBatchPath guards against VerifyPredicates falling
+// back to per-predicate bls.Verify without anyone noticing: it asserts the
+// batched multi-pairing check in BatchVerify actually succeeds for a block's
+// worth of valid predicates, the same way TestBatchVerifyTakesBatchPath does
+// for BatchVerify directly.
+func TestVerifyPredicatesTakesBatchPath(t *testing.T) {
+	require := require.New(t)
+	predicateBytes, nodeID, pk := newSignedPredicates(t, 10)
+	pChainState := newTestValidatorState(nodeID, pk, 10)
+
+	successesBefore := atomic.LoadInt64(&batchVerifySuccesses)
+	require.NoError(VerifyPredicates(pChainState, 10, predicateBytes))
+	require.Equal(successesBefore+1, atomic.LoadInt64(&batchVerifySuccesses))
+}
+
+func TestVerifyPredicatesLocalizesTamperedPredicate(t *testing.T) {
+	require := require.New(t)
+	predicateBytes, nodeID, pk := newSignedPredicates(t, 10)
+	pChainState := newTestValidatorState(nodeID, pk, 10)
+
+	const tamperedIndex = 4
+	tamperedMessage, err := avalancheWarp.ParseMessage(predicateBytes[tamperedIndex])
+	require.NoError(err)
+	tamperedSignature := *tamperedMessage.Signature.(*avalancheWarp.BitSetSignature)
+	tamperedSignature.Signature[0] ^= 0xFF
+	tampered, err := avalancheWarp.NewMessage(tamperedMessage.UnsignedMessage, &tamperedSignature)
+	require.NoError(err)
+	predicateBytes[tamperedIndex] = tampered.Bytes()
+
+	err = VerifyPredicates(pChainState, 10, predicateBytes)
+	require.Error(err)
+	var batchErr *BatchVerifyError
+	require.ErrorAs(err, &batchErr)
+	require.Equal(tamperedIndex, batchErr.Index)
+}
@@ -0,0 +1,262 @@
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/params"
+	warpPayload "github.com/ava-labs/subnet-evm/warp/payload"
+)
+
+// Candidate bundles everything a Requirement may need to evaluate a single
+// warp message. Not every Requirement reads every field: RequireNotExpired
+// only cares about Timestamp, while RequireAggregateSignatureValid needs the
+// canonical validator set backing Signature.
+type Candidate struct {
+	Unsigned    *avalancheWarp.UnsignedMessage
+	Signature   *avalancheWarp.BitSetSignature
+	Validators  []*avalancheWarp.Validator // canonical set at the verified P-Chain height
+	TotalWeight uint64
+	Timestamp   time.Time
+}
+
+// Requirement is a single, independently testable check applied to a
+// Candidate during warp message verification. Requirements are composed into
+// an ordered Verifier so that the set of checks applied to a message can be
+// extended (e.g. with an allowlist or a min-stake override) without changing
+// the predicate evaluation code path itself.
+type Requirement interface {
+	// Name returns a short, stable identifier used in Result reporting and
+	// error messages.
+	Name() string
+	// Evaluate returns nil if c satisfies the requirement, or an error
+	// describing why it does not.
+	Evaluate(c *Candidate) error
+}
+
+// Status describes the outcome of evaluating a single Requirement against a
+// Candidate.
+type Status int
+
+const (
+	StatusSkipped Status = iota
+	StatusPassed
+	StatusFailed
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusPassed:
+		return "passed"
+	case StatusFailed:
+		return "failed"
+	default:
+		return "skipped"
+	}
+}
+
+// Result records the outcome of running a Verifier's Requirement list against
+// a single Candidate, keyed by requirement name.
+type Result struct {
+	Statuses map[string]Status
+	// Err is the error returned by the first failing Requirement, or nil if
+	// every Requirement passed.
+	Err error
+}
+
+// Passed reports whether every Requirement in the list passed.
+func (r Result) Passed() bool {
+	return r.Err == nil
+}
+
+// Verifier runs an ordered list of Requirements against a Candidate,
+// short-circuiting on the first failure.
+type Verifier struct {
+	requirements []Requirement
+}
+
+// NewVerifier returns a Verifier that evaluates requirements in order.
+func NewVerifier(requirements ...Requirement) *Verifier {
+	return &Verifier{requirements: requirements}
+}
+
+// Verify runs v's requirements against c in order, stopping at the first
+// failure. Requirements after a failure are recorded as skipped.
+func (v *Verifier) Verify(c *Candidate) Result {
+	result := Result{Statuses: make(map[string]Status, len(v.requirements))}
+	failed := false
+	for _, requirement := range v.requirements {
+		if failed {
+			result.Statuses[requirement.Name()] = StatusSkipped
+			continue
+		}
+		if err := requirement.Evaluate(c); err != nil {
+			result.Statuses[requirement.Name()] = StatusFailed
+			result.Err = fmt.Errorf("%s: %w", requirement.Name(), err)
+			failed = true
+			continue
+		}
+		result.Statuses[requirement.Name()] = StatusPassed
+	}
+	return result
+}
+
+// PredicateVerificationRequirements is the default Requirement list applied
+// by VerifyPredicate and VerifyPredicates when evaluating warp message
+// predicates.
+var PredicateVerificationRequirements = []Requirement{
+	RequireUnsignedMessageWellFormed(),
+	RequireSourceSubnetAllowed(),
+	RequireQuorumWeight(params.WarpDefaultQuorumNumerator, params.WarpQuorumDenominator),
+	RequireAggregateSignatureValid(),
+}
+
+// AcceptedMessageRequirements is the default Requirement list that the warp
+// backend's Accept applies to a message before signing it.
+var AcceptedMessageRequirements = []Requirement{
+	RequireUnsignedMessageWellFormed(),
+	RequirePayloadCodecKnown(),
+}
+
+type requirementFunc struct {
+	name string
+	fn   func(c *Candidate) error
+}
+
+func (r *requirementFunc) Name() string { return r.name }
+
+func (r *requirementFunc) Evaluate(c *Candidate) error { return r.fn(c) }
+
+func signersOf(sig *avalancheWarp.BitSetSignature) (set.Bits, error) {
+	return set.BitsFromBytes(sig.Signers)
+}
+
+// RequireUnsignedMessageWellFormed fails if the candidate's unsigned message
+// or signature is missing or malformed.
+func RequireUnsignedMessageWellFormed() Requirement {
+	return &requirementFunc{
+		name: "unsigned-message-well-formed",
+		fn: func(c *Candidate) error {
+			if c.Unsigned == nil {
+				return fmt.Errorf("missing unsigned message")
+			}
+			if len(c.Unsigned.Bytes()) == 0 {
+				return fmt.Errorf("empty unsigned message")
+			}
+			if c.Signature == nil {
+				return fmt.Errorf("missing signature")
+			}
+			return nil
+		},
+	}
+}
+
+// RequireSourceSubnetAllowed fails unless the candidate's source chain ID is
+// one of allowedSubnets. The IDs are compared with the source chain ID
+// itself; the subnet that chain belongs to is not looked up.
+// With no allowedSubnets, every source is allowed.
+func RequireSourceSubnetAllowed(allowedSubnets ...ids.ID) Requirement {
+	allowed := make(map[ids.ID]struct{}, len(allowedSubnets))
+	for _, subnetID := range allowedSubnets {
+		allowed[subnetID] = struct{}{}
+	}
+	return &requirementFunc{
+		name: "source-subnet-allowed",
+		fn: func(c *Candidate) error {
+			if len(allowed) == 0 {
+				return nil
+			}
+			if _, ok := allowed[c.Unsigned.SourceChainID]; !ok {
+				return fmt.Errorf("source chain %s is not an allowed subnet", c.Unsigned.SourceChainID)
+			}
+			return nil
+		},
+	}
+}
+
+// RequireQuorumWeight fails unless the weight backing the candidate's
+// signature is at least numerator/denominator of the total validator weight.
+func RequireQuorumWeight(numerator, denominator uint64) Requirement {
+	return &requirementFunc{
+		name: "quorum-weight",
+		fn: func(c *Candidate) error {
+			if c.TotalWeight == 0 {
+				return fmt.Errorf("total validator weight is zero")
+			}
+			signers, err := signersOf(c.Signature)
+			if err != nil {
+				return fmt.Errorf("invalid signer bitset: %w", err)
+			}
+			signedWeight := avalancheWarp.SumWeight(avalancheWarp.FilterValidators(signers, c.Validators))
+			if err := avalancheWarp.VerifyWeight(signedWeight, c.TotalWeight, numerator, denominator); err != nil {
+				return fmt.Errorf("quorum not met: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// RequireAggregateSignatureValid fails unless the candidate's aggregate BLS
+// signature verifies against the aggregate public key of the signers it
+// claims, over the candidate's unsigned message bytes.
+func RequireAggregateSignatureValid() Requirement {
+	return &requirementFunc{
+		name: "aggregate-signature-valid",
+		fn: func(c *Candidate) error {
+			signers, err := signersOf(c.Signature)
+			if err != nil {
+				return fmt.Errorf("invalid signer bitset: %w", err)
+			}
+			signedVdrs := avalancheWarp.FilterValidators(signers, c.Validators)
+			aggregatePublicKey, err := avalancheWarp.AggregatePublicKeys(signedVdrs)
+			if err != nil {
+				return fmt.Errorf("failed to aggregate public keys: %w", err)
+			}
+			sig, err := bls.SignatureFromBytes(c.Signature.Signature[:])
+			if err != nil {
+				return fmt.Errorf("invalid aggregate signature bytes: %w", err)
+			}
+			if !bls.Verify(aggregatePublicKey, sig, c.Unsigned.Bytes()) {
+				return fmt.Errorf("aggregate signature does not verify")
+			}
+			return nil
+		},
+	}
+}
+
+// RequireNotExpired fails if the candidate's Timestamp is older than maxAge.
+// A zero Timestamp is treated as "not applicable" and always passes.
+func RequireNotExpired(maxAge time.Duration) Requirement {
+	return &requirementFunc{
+		name: "not-expired",
+		fn: func(c *Candidate) error {
+			if c.Timestamp.IsZero() {
+				return nil
+			}
+			if age := time.Since(c.Timestamp); age > maxAge {
+				return fmt.Errorf("message age %s exceeds max age %s", age, maxAge)
+			}
+			return nil
+		},
+	}
+}
+
+// RequirePayloadCodecKnown fails if the candidate's payload cannot be parsed
+// by any payload codec this chain knows about.
+func RequirePayloadCodecKnown() Requirement {
+	return &requirementFunc{
+		name: "payload-codec-known",
+		fn: func(c *Candidate) error {
+			if _, err := warpPayload.Parse(c.Unsigned.Payload); err != nil {
+				return fmt.Errorf("unknown payload codec: %w", err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,152 @@
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/subnet-evm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCandidate(t *testing.T) (*Candidate, []*bls.SecretKey) {
+	require := require.New(t)
+
+	addressedPayload, err := warpPayload.NewAddressedPayload(
+		common.Address{1},
+		ids.GenerateTestID().Hash(),
+		common.Address{2},
+		[]byte("hello"),
+	)
+	require.NoError(err)
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(0, ids.GenerateTestID(), addressedPayload.Bytes())
+	require.NoError(err)
+
+	sk1, err := bls.NewSecretKey()
+	require.NoError(err)
+	sk2, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	sig1 := bls.Sign(sk1, unsignedMessage.Bytes())
+	sig2 := bls.Sign(sk2, unsignedMessage.Bytes())
+	aggSig, err := bls.AggregateSignatures([]*bls.Signature{sig1, sig2})
+	require.NoError(err)
+
+	signers := set.NewBits()
+	signers.Add(0)
+	signers.Add(1)
+	signature := &avalancheWarp.BitSetSignature{Signers: signers.Bytes()}
+	copy(signature.Signature[:], bls.SignatureToBytes(aggSig))
+
+	validators := []*avalancheWarp.Validator{
+		{PublicKey: bls.PublicFromSecretKey(sk1), Weight: 50, NodeIDs: []ids.NodeID{ids.GenerateTestNodeID()}},
+		{PublicKey: bls.PublicFromSecretKey(sk2), Weight: 50, NodeIDs: []ids.NodeID{ids.GenerateTestNodeID()}},
+	}
+
+	return &Candidate{
+		Unsigned:    unsignedMessage,
+		Signature:   signature,
+		Validators:  validators,
+		TotalWeight: 100,
+		Timestamp:   time.Now(),
+	}, []*bls.SecretKey{sk1, sk2}
+}
+
+func TestRequireUnsignedMessageWellFormed(t *testing.T) {
+	require := require.New(t)
+	c, _ := newTestCandidate(t)
+	require.NoError(RequireUnsignedMessageWellFormed().Evaluate(c))
+
+	missingUnsigned := *c
+	missingUnsigned.Unsigned = nil
+	require.Error(RequireUnsignedMessageWellFormed().Evaluate(&missingUnsigned))
+
+	missingSig := *c
+	missingSig.Signature = nil
+	require.Error(RequireUnsignedMessageWellFormed().Evaluate(&missingSig))
+}
+
+func TestRequireSourceSubnetAllowed(t *testing.T) {
+	require := require.New(t)
+	c, _ := newTestCandidate(t)
+
+	require.NoError(RequireSourceSubnetAllowed().Evaluate(c))
+	require.Error(RequireSourceSubnetAllowed(ids.GenerateTestID()).Evaluate(c))
+	require.NoError(RequireSourceSubnetAllowed(c.Unsigned.SourceChainID).Evaluate(c))
+}
+
+func TestRequireQuorumWeight(t *testing.T) {
+	require := require.New(t)
+	c, _ := newTestCandidate(t)
+
+	require.NoError(RequireQuorumWeight(67, 100).Evaluate(c))
+	require.Error(RequireQuorumWeight(101, 100).Evaluate(c))
+
+	noWeight := *c
+	noWeight.TotalWeight = 0
+	require.Error(RequireQuorumWeight(67, 100).Evaluate(&noWeight))
+}
+
+func TestRequireAggregateSignatureValid(t *testing.T) {
+	require := require.New(t)
+	c, _ := newTestCandidate(t)
+	require.NoError(RequireAggregateSignatureValid().Evaluate(c))
+
+	tampered := *c
+	tamperedSignature := *c.Signature
+	tamperedSignature.Signature[0] ^= 0xFF
+	tampered.Signature = &tamperedSignature
+	require.Error(RequireAggregateSignatureValid().Evaluate(&tampered))
+}
+
+func TestRequireNotExpired(t *testing.T) {
+	require := require.New(t)
+	c, _ := newTestCandidate(t)
+
+	require.NoError(RequireNotExpired(time.Hour).Evaluate(c))
+
+	stale := *c
+	stale.Timestamp = time.Now().Add(-2 * time.Hour)
+	require.Error(RequireNotExpired(time.Hour).Evaluate(&stale))
+}
+
+func TestRequirePayloadCodecKnown(t *testing.T) {
+	require := require.New(t)
+	c, _ := newTestCandidate(t)
+	require.NoError(RequirePayloadCodecKnown().Evaluate(c))
+
+	badPayload := *c
+	unsignedBad, err := avalancheWarp.NewUnsignedMessage(0, ids.GenerateTestID(), []byte{0xFF, 0xFF})
+	require.NoError(err)
+	badPayload.Unsigned = unsignedBad
+	require.Error(RequirePayloadCodecKnown().Evaluate(&badPayload))
+}
+
+func TestVerifierResult(t *testing.T) {
+	require := require.New(t)
+	c, _ := newTestCandidate(t)
+
+	v := NewVerifier(PredicateVerificationRequirements...)
+	result := v.Verify(c)
+	require.True(result.Passed())
+	for _, requirement := range PredicateVerificationRequirements {
+		require.Equal(StatusPassed, result.Statuses[requirement.Name()])
+	}
+
+	v = NewVerifier(
+		RequireUnsignedMessageWellFormed(),
+		RequireSourceSubnetAllowed(ids.GenerateTestID()),
+		RequireQuorumWeight(67, 100),
+	)
+	result = v.Verify(c)
+	require.False(result.Passed())
+	require.Equal(StatusPassed, result.Statuses["unsigned-message-well-formed"])
+	require.Equal(StatusFailed, result.Statuses["source-subnet-allowed"])
+	require.Equal(StatusSkipped, result.Statuses["quorum-weight"])
+}